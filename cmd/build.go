@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/chrisdail/pile/core"
+	"github.com/spf13/cobra"
+)
+
+var buildPush bool
+
+// buildCmd implements `pile build`, building (and testing) each project's image through its
+// configured container backend
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build project images",
+	RunE:  runBuild,
+}
+
+func init() {
+	buildCmd.Flags().BoolVar(&buildPush, "push", false, "Push built images to their registry")
+	rootCmd.AddCommand(buildCmd)
+}
+
+func runBuild(cmd *cobra.Command, args []string) error {
+	projects, err := loadProjects()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	failed := false
+	for _, project := range projects {
+		if !project.CanBuild {
+			continue
+		}
+		if !project.Enabled {
+			fmt.Printf("%s skipped (--tags)\n", project.Config.Name)
+			continue
+		}
+
+		if err := core.Build(ctx, project); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+
+		if buildPush {
+			if err := project.Backend.Push(ctx, project.ImageWithRegistry); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed = true
+				continue
+			}
+		}
+		fmt.Printf("%s -> %s\n", project.Config.Name, project.Image)
+	}
+
+	if failed {
+		return fmt.Errorf("build: one or more projects failed to build")
+	}
+	return nil
+}
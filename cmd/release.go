@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chrisdail/pile/release"
+	"github.com/spf13/cobra"
+)
+
+var releaseBump string
+var releasePush bool
+
+// releaseCmd implements `pile release`, bumping each buildable project's SemVer version
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Bump project versions from their nearest git tag",
+	RunE:  runRelease,
+}
+
+func init() {
+	releaseCmd.Flags().StringVar(&releaseBump, "bump", string(release.BumpPatch), "Version component to bump: major, minor, patch or dev")
+	releaseCmd.Flags().BoolVar(&releasePush, "push", false, "Create and push the annotated release tag")
+	rootCmd.AddCommand(releaseCmd)
+}
+
+func runRelease(cmd *cobra.Command, args []string) error {
+	bump := release.Bump(releaseBump)
+	switch bump {
+	case release.BumpMajor, release.BumpMinor, release.BumpPatch, release.BumpDev:
+	default:
+		return fmt.Errorf("unknown --bump value %q", releaseBump)
+	}
+
+	projects, err := loadProjects()
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for _, project := range projects {
+		if !project.CanBuild {
+			continue
+		}
+
+		opts := release.Options{Bump: bump, PushTag: releasePush}
+		if err := release.Run(project, opts); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s -> %s\n", project.Config.Name, project.Tag)
+	}
+
+	if failed {
+		return fmt.Errorf("release: one or more projects failed to release")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/chrisdail/pile/core"
+)
+
+// tagsFlagEnvVar overrides --tags when set
+const tagsFlagEnvVar = "PILE_TAGS"
+
+var tagsFlag string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&tagsFlag, "tags", "", "Comma-separated build tags to activate, e.g. release,arm64")
+}
+
+// requestedTags parses the active --tags/PILE_TAGS value into a core.TagSet
+func requestedTags() core.TagSet {
+	value := tagsFlag
+	if envTags := os.Getenv(tagsFlagEnvVar); envTags != "" {
+		value = envTags
+	}
+	return core.ParseTagSet(value)
+}
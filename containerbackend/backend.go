@@ -0,0 +1,39 @@
+// Package containerbackend abstracts the tool used to build, push and inspect container
+// images so pile can target Docker, Buildah or future tools (kaniko, nerdctl, ...) through
+// a single interface
+package containerbackend
+
+import "context"
+
+// BuildOptions describes a single image build
+type BuildOptions struct {
+	// ContextDir is the directory the image is built from
+	ContextDir string
+	// Dockerfile is the path to the Dockerfile/Containerfile, passed as given to the tool's -f
+	// flag; empty leaves the tool's default
+	Dockerfile string
+	// Tag is the full image reference to build, e.g. "myimage:1.2.3"
+	Tag string
+	// Target is the optional multi-stage build target to stop at
+	Target string
+	// BuildArgs are passed through as build-time arguments
+	BuildArgs map[string]string
+}
+
+// Info is image metadata as reported by the backend
+type Info struct {
+	ID   string
+	Tags []string
+}
+
+// Backend builds and manages container images via a specific tool
+type Backend interface {
+	// Build builds an image as described by opts
+	Build(ctx context.Context, opts BuildOptions) error
+	// Push pushes image to its registry
+	Push(ctx context.Context, image string) error
+	// Cp copies src out of a container created from image into the local dst path
+	Cp(ctx context.Context, image, src, dst string) error
+	// Inspect returns metadata about image
+	Inspect(ctx context.Context, image string) (Info, error)
+}
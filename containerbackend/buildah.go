@@ -0,0 +1,73 @@
+package containerbackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// BuildahBackend builds images via the buildah CLI, enabling daemonless, rootless builds
+// without requiring the Docker Engine
+type BuildahBackend struct{}
+
+// Build runs `buildah bud`
+func (BuildahBackend) Build(ctx context.Context, opts BuildOptions) error {
+	args := []string{"bud", "-t", opts.Tag}
+	if opts.Dockerfile != "" {
+		args = append(args, "-f", opts.Dockerfile)
+	}
+	if opts.Target != "" {
+		args = append(args, "--target", opts.Target)
+	}
+	for name, value := range opts.BuildArgs {
+		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", name, value))
+	}
+	args = append(args, opts.ContextDir)
+
+	return runDockerLike(ctx, "buildah", args...)
+}
+
+// Push runs `buildah push`
+func (BuildahBackend) Push(ctx context.Context, image string) error {
+	return runDockerLike(ctx, "buildah", "push", image)
+}
+
+// Cp copies src out of image by creating a working container, mounting it and copying from the
+// mount point, since buildah has no equivalent of `docker cp` against a stopped container
+func (BuildahBackend) Cp(ctx context.Context, image, src, dst string) error {
+	container, err := exec.CommandContext(ctx, "buildah", "from", image).Output()
+	if err != nil {
+		return fmt.Errorf("buildah: creating container from %s: %w", image, err)
+	}
+	containerName := strings.TrimSpace(string(container))
+	defer exec.CommandContext(ctx, "buildah", "rm", containerName).Run()
+
+	mountPoint, err := exec.CommandContext(ctx, "buildah", "mount", containerName).Output()
+	if err != nil {
+		return fmt.Errorf("buildah: mounting %s: %w", containerName, err)
+	}
+	defer exec.CommandContext(ctx, "buildah", "umount", containerName).Run()
+
+	return runDockerLike(ctx, "cp", "-r", strings.TrimSpace(string(mountPoint))+"/"+strings.TrimPrefix(src, "/"), dst)
+}
+
+// Inspect runs `buildah inspect`
+func (BuildahBackend) Inspect(ctx context.Context, image string) (Info, error) {
+	output, err := exec.CommandContext(ctx, "buildah", "inspect", "-t", "image", image).Output()
+	if err != nil {
+		return Info{}, fmt.Errorf("buildah: inspecting %s: %w", image, err)
+	}
+
+	var parsed struct {
+		FromImageID string `json:"FromImageID"`
+		Docker      struct {
+			RepoTags []string `json:"RepoTags"`
+		} `json:"Docker"`
+	}
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return Info{}, fmt.Errorf("buildah: parsing inspect output for %s: %w", image, err)
+	}
+	return Info{ID: parsed.FromImageID, Tags: parsed.Docker.RepoTags}, nil
+}
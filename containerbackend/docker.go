@@ -0,0 +1,84 @@
+package containerbackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// DockerBackend builds images via the docker CLI. This is pile's original, default backend
+type DockerBackend struct{}
+
+// Build runs `docker build`
+func (DockerBackend) Build(ctx context.Context, opts BuildOptions) error {
+	args := []string{"build", "-t", opts.Tag}
+	if opts.Dockerfile != "" {
+		args = append(args, "-f", opts.Dockerfile)
+	}
+	if opts.Target != "" {
+		args = append(args, "--target", opts.Target)
+	}
+	for name, value := range opts.BuildArgs {
+		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", name, value))
+	}
+	args = append(args, opts.ContextDir)
+
+	return runDockerLike(ctx, "docker", args...)
+}
+
+// Push runs `docker push`
+func (DockerBackend) Push(ctx context.Context, image string) error {
+	return runDockerLike(ctx, "docker", "push", image)
+}
+
+// Cp runs `docker create` followed by `docker cp` and `docker rm` to copy files out of an image
+// without running it
+func (DockerBackend) Cp(ctx context.Context, image, src, dst string) error {
+	cmd := exec.CommandContext(ctx, "docker", "create", image)
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("docker: creating container from %s: %w", image, err)
+	}
+	container := string(output)
+	if len(container) > 0 && container[len(container)-1] == '\n' {
+		container = container[:len(container)-1]
+	}
+	defer exec.CommandContext(ctx, "docker", "rm", container).Run()
+
+	return runDockerLike(ctx, "docker", "cp", fmt.Sprintf("%s:%s", container, src), dst)
+}
+
+// Inspect runs `docker inspect`
+func (DockerBackend) Inspect(ctx context.Context, image string) (Info, error) {
+	cmd := exec.CommandContext(ctx, "docker", "inspect", image)
+	output, err := cmd.Output()
+	if err != nil {
+		return Info{}, fmt.Errorf("docker: inspecting %s: %w", image, err)
+	}
+
+	var parsed []struct {
+		ID       string   `json:"Id"`
+		RepoTags []string `json:"RepoTags"`
+	}
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		return Info{}, fmt.Errorf("docker: parsing inspect output for %s: %w", image, err)
+	}
+	if len(parsed) == 0 {
+		return Info{}, fmt.Errorf("docker: no image found for %s", image)
+	}
+	return Info{ID: parsed[0].ID, Tags: parsed[0].RepoTags}, nil
+}
+
+// runDockerLike runs an exec.Cmd with stdout/stderr wired to the current process, as used for
+// all docker/buildah subcommands whose own output should stream directly to the user
+func runDockerLike(ctx context.Context, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %v: %w", name, args, err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package containerbackend
+
+import "fmt"
+
+// DockerName and BuildahName are the accepted values for the `backend` config key and the
+// PILE_BACKEND environment variable
+const (
+	DockerName  = "docker"
+	BuildahName = "buildah"
+)
+
+// New returns the Backend identified by name. An empty name selects DockerBackend
+func New(name string) (Backend, error) {
+	switch name {
+	case "", DockerName:
+		return DockerBackend{}, nil
+	case BuildahName:
+		return BuildahBackend{}, nil
+	default:
+		return nil, fmt.Errorf("containerbackend: unknown backend %q", name)
+	}
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/chrisdail/pile/containerbackend"
+)
+
+// Build builds project's image through its configured container backend. When Config.Test.Target
+// is set, that stage is built and must succeed before the final image is built; CopyResults, if
+// configured, copies test output out of the test-stage image first
+func Build(ctx context.Context, project *Project) error {
+	if target := project.Config.Test.Target; target != "" {
+		if err := buildStage(ctx, project, target); err != nil {
+			return fmt.Errorf("build: %s: running tests: %w", project.Config.Name, err)
+		}
+
+		if dst := project.Config.Test.CopyResults.DstPath; dst != "" {
+			src := project.Config.Test.CopyResults.SrcPath
+			if err := project.Backend.Cp(ctx, project.Image, src, filepath.Join(project.Dir, dst)); err != nil {
+				return fmt.Errorf("build: %s: copying test results: %w", project.Config.Name, err)
+			}
+		}
+	}
+
+	if err := buildStage(ctx, project, ""); err != nil {
+		return fmt.Errorf("build: %s: %w", project.Config.Name, err)
+	}
+	return nil
+}
+
+// buildStage builds project's image to the given multi-stage target, or the final stage when
+// target is empty
+func buildStage(ctx context.Context, project *Project, target string) error {
+	return project.Backend.Build(ctx, containerbackend.BuildOptions{
+		ContextDir: project.ContextDir(),
+		Dockerfile: filepath.Join(project.Dir, dockerfile),
+		Tag:        project.Image,
+		Target:     target,
+		BuildArgs:  project.Config.BuildArgs,
+	})
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// LoadAll constructs and loads a Project for each of dirs concurrently, sharing a single
+// RepoContext and TagSet across all of them. Concurrency is bounded to GOMAXPROCS so large
+// repositories don't spawn unbounded goroutines
+func LoadAll(dirs []string, defaults *ProjectConfig, tags TagSet, repoCtx *RepoContext) ([]*Project, error) {
+	projects := make([]*Project, len(dirs))
+
+	var g errgroup.Group
+	g.SetLimit(runtime.GOMAXPROCS(0))
+
+	for i, dir := range dirs {
+		i, dir := i, dir
+		g.Go(func() error {
+			project := &Project{Dir: dir}
+			if err := project.Load(defaults, tags, repoCtx); err != nil {
+				return fmt.Errorf("loading %s: %w", dir, err)
+			}
+			projects[i] = project
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
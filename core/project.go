@@ -6,13 +6,18 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/chrisdail/pile/containerbackend"
 	"github.com/chrisdail/pile/gitver"
 	"github.com/chrisdail/pile/registry"
 	"github.com/imdario/mergo"
 	"gopkg.in/yaml.v2"
 )
 
+// backendEnvVar overrides the configured backend for every project when set
+const backendEnvVar = "PILE_BACKEND"
+
 const pileConfigName = "pile.yml"
 const dockerfile = "Dockerfile"
 
@@ -33,6 +38,13 @@ type ProjectConfig struct {
 	// Template for computing the version strong
 	VersionTemplate string `yaml:"version_template"`
 
+	// Glob pattern matching the tags considered when locating the nearest SemVer tag (e.g. "v*").
+	// Defaults to gitver.DefaultTagPattern
+	TagPattern string `yaml:"tag_pattern"`
+
+	// Branches `pile release` is allowed to run from. Empty allows releasing from any branch
+	ReleaseBranches []string `yaml:"release_branches"`
+
 	// Relative paths to other projects that this project depends on. These are incorporated into the version string
 	DependsOn []string `yaml:"depends_on"`
 
@@ -53,8 +65,21 @@ type ProjectConfig struct {
 		} `yaml:"copy_results"`
 	}
 
+	// Tags this project builds under. A project with no Tags always builds. A project with Tags
+	// builds only if at least one is requested via --tags/PILE_TAGS, unless prefixed with "!", in
+	// which case the project is skipped whenever that tag is requested
+	Tags []string `yaml:"tags"`
+
+	// Extra build_args merged in when the map's key is a requested tag, e.g.
+	// build_args_by_tag: { release: { OPTIMIZE: "1" } }
+	BuildArgsByTag map[string]map[string]string `yaml:"build_args_by_tag"`
+
 	// Docker registry settings for pushing images to and caching already built images
 	Registry registry.Config
+
+	// Backend selects the container tool used to build and push images: "docker" (default) or
+	// "buildah". Overridden by the PILE_BACKEND environment variable
+	Backend string
 }
 
 // Project data about an active project
@@ -63,15 +88,21 @@ type Project struct {
 
 	Config            ProjectConfig
 	CanBuild          bool
+	Enabled           bool
 	GitVersion        *gitver.GitVersion
+	Backend           containerbackend.Backend
 	Repository        string
 	Tag               string
 	Image             string
 	ImageWithRegistry string
 }
 
-// Load loads a project given a set of defaults from the root
-func (project *Project) Load(defaults *ProjectConfig) error {
+// Load loads a project given a set of defaults from the root, the tags requested via
+// --tags/PILE_TAGS, and a RepoContext shared across every project being loaded so the VCS is
+// detected once and, for git, the repository is opened once and each distinct path's history is
+// walked at most once. Each distinct path still gets its own walk from HEAD; there is no single
+// walk shared by all paths
+func (project *Project) Load(defaults *ProjectConfig, tags TagSet, repoCtx *RepoContext) error {
 	configPath := filepath.Join(project.Dir, pileConfigName)
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		log.Printf("Config file does not exist: %s", configPath)
@@ -109,8 +140,46 @@ func (project *Project) Load(defaults *ProjectConfig) error {
 		project.CanBuild = true
 	}
 
-	// Load version for this project
-	project.GitVersion, err = gitver.New(project.versionedPaths())
+	project.Enabled = project.shouldBuild(tags)
+
+	// Merge in build args contributed by any requested tag. BuildArgs may currently be the same
+	// map instance mergo assigned by reference from defaults, which is shared across every
+	// project's Load call in the concurrent LoadAll fan-out, so clone it before mutating rather
+	// than risk cross-contaminating (and racing on) another project's map
+	if len(project.Config.BuildArgsByTag) > 0 {
+		buildArgs := map[string]string{}
+		for name, value := range project.Config.BuildArgs {
+			buildArgs[name] = value
+		}
+
+		for tag, extra := range project.Config.BuildArgsByTag {
+			if !tags.Has(tag) {
+				continue
+			}
+			for name, value := range extra {
+				buildArgs[name] = value
+			}
+		}
+		project.Config.BuildArgs = buildArgs
+	}
+
+	// Load version for this project, reusing the shared RepoContext instead of re-detecting and
+	// re-reading the repository for every project
+	info, err := repoCtx.Info(project.versionedPaths())
+	if err != nil {
+		return err
+	}
+	project.GitVersion, err = gitver.FromInfo(info, repoCtx.GitContext(), project.Config.TagPattern)
+	if err != nil {
+		return err
+	}
+
+	// Select the container backend for this project, letting PILE_BACKEND override pile.yml
+	backendName := project.Config.Backend
+	if envBackend := os.Getenv(backendEnvVar); envBackend != "" {
+		backendName = envBackend
+	}
+	project.Backend, err = containerbackend.New(backendName)
 	if err != nil {
 		return err
 	}
@@ -146,6 +215,28 @@ func (project *Project) versionedPaths() []string {
 	return paths
 }
 
+// shouldBuild reports whether project should build given the requested tags. A project with no
+// Tags always builds. A project with only "!negated" Tags builds unless a negated tag is
+// requested. Otherwise the project builds only if at least one of its Tags is requested
+func (project *Project) shouldBuild(tags TagSet) bool {
+	hasPositiveTag := false
+	matched := false
+	for _, tag := range project.Config.Tags {
+		if negated := strings.TrimPrefix(tag, "!"); negated != tag {
+			if tags.Has(negated) {
+				return false
+			}
+			continue
+		}
+
+		hasPositiveTag = true
+		if tags.Has(tag) {
+			matched = true
+		}
+	}
+	return !hasPositiveTag || matched
+}
+
 // ContextDir returns the context directory absolute path
 func (project *Project) ContextDir() string {
 	if project.Config.ContextDir != "" {
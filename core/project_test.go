@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestShouldBuild(t *testing.T) {
+	tests := []struct {
+		name      string
+		tags      []string
+		requested string
+		want      bool
+	}{
+		{"no tags always builds", nil, "", true},
+		{"positive tag requested", []string{"release"}, "release", true},
+		{"positive tag not requested", []string{"release"}, "", false},
+		{"other tag requested doesn't satisfy positive tag", []string{"release"}, "arm64", false},
+		{"negated tag not requested builds", []string{"!slow"}, "", true},
+		{"negated tag requested skips", []string{"!slow"}, "slow", false},
+		{"positive and negated: negation wins even if positive matches", []string{"release", "!slow"}, "release,slow", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			project := &Project{Config: ProjectConfig{Tags: test.tags}}
+			if got := project.shouldBuild(ParseTagSet(test.requested)); got != test.want {
+				t.Errorf("shouldBuild(%v) with tags %v = %v, want %v", test.requested, test.tags, got, test.want)
+			}
+		})
+	}
+}
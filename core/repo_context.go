@@ -0,0 +1,52 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/chrisdail/pile/vcs"
+)
+
+// RepoContext caches repository-wide VCS state so Load can avoid re-detecting the VCS for every
+// project and, for git, re-reading the same repository. Mercurial and Subversion are still
+// queried afresh on every Info call. Construct one with NewRepoContext at the root and pass it to
+// every Project.Load call under that root
+type RepoContext struct {
+	cmd    vcs.Cmd
+	root   string
+	gitCtx *vcs.GitContext
+}
+
+// NewRepoContext detects the VCS at root and prepares a RepoContext for Load calls under it
+func NewRepoContext(root string) (*RepoContext, error) {
+	cmd, detectedRoot, err := vcs.Detect(root)
+	if err != nil {
+		return nil, fmt.Errorf("core: %w", err)
+	}
+
+	ctx := &RepoContext{cmd: cmd, root: detectedRoot}
+	if _, ok := cmd.(vcs.Git); ok {
+		if ctx.gitCtx, err = vcs.NewGitContext(detectedRoot); err != nil {
+			return nil, fmt.Errorf("core: %w", err)
+		}
+	}
+	return ctx, nil
+}
+
+// Info computes version control information for paths, reusing cached repository state
+func (repoCtx *RepoContext) Info(paths []string) (vcs.Info, error) {
+	if repoCtx.gitCtx != nil {
+		return repoCtx.gitCtx.Info(paths)
+	}
+	return repoCtx.cmd.Info(repoCtx.root, paths)
+}
+
+// Root returns the detected repository root
+func (repoCtx *RepoContext) Root() string {
+	return repoCtx.root
+}
+
+// GitContext returns the cached git-specific state backing this RepoContext, or nil if the
+// detected VCS isn't git, i.e. if no SemVer/tag information is available
+func (repoCtx *RepoContext) GitContext() *vcs.GitContext {
+	return repoCtx.gitCtx
+}
@@ -0,0 +1,24 @@
+package core
+
+import "strings"
+
+// TagSet is the set of build tags requested via --tags/PILE_TAGS
+type TagSet struct {
+	active map[string]bool
+}
+
+// ParseTagSet parses a comma-separated tag list, e.g. "release,arm64", into a TagSet
+func ParseTagSet(value string) TagSet {
+	set := TagSet{active: map[string]bool{}}
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			set.active[tag] = true
+		}
+	}
+	return set
+}
+
+// Has reports whether tag was requested
+func (set TagSet) Has(tag string) bool {
+	return set.active[tag]
+}
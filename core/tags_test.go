@@ -0,0 +1,24 @@
+package core
+
+import "testing"
+
+func TestParseTagSet(t *testing.T) {
+	set := ParseTagSet(" release, arm64 ,,")
+
+	if !set.Has("release") {
+		t.Error(`set.Has("release") = false, want true`)
+	}
+	if !set.Has("arm64") {
+		t.Error(`set.Has("arm64") = false, want true`)
+	}
+	if set.Has("other") {
+		t.Error(`set.Has("other") = true, want false`)
+	}
+}
+
+func TestParseTagSetEmpty(t *testing.T) {
+	set := ParseTagSet("")
+	if set.Has("anything") {
+		t.Error(`ParseTagSet("").Has("anything") = true, want false`)
+	}
+}
@@ -1,25 +1,102 @@
 package gitver
 
 import (
+	"fmt"
 	"log"
 	"os/user"
 	"regexp"
 	"strings"
 	"text/template"
+
+	"github.com/Masterminds/semver"
+	"github.com/chrisdail/pile/vcs"
 )
 
 // DefaultTemplate Default template for formatting GitVersion using String()
 const DefaultTemplate = "{{if .Dirty}}dirty-{{.User}}-{{end}}{{.Commits}}.{{.Hash}}"
 
+// DefaultTagPattern matches the tags considered when locating the nearest SemVer tag
+const DefaultTagPattern = "v*"
+
 var sanitizedUserCache = &cachedStringResponse{}
 
-// GitVersion version information about one or more git projects
+// GitVersion version information about one or more project paths, sourced from whichever
+// version control system vcs.Detect finds at those paths
 type GitVersion struct {
 	Branch  string
 	Commits string
 	Hash    string
 	Dirty   bool
 	User    string
+
+	// Tag is the name of the nearest reachable git tag matching the configured pattern, if any.
+	// Only populated for projects backed by git
+	Tag string
+	// TagDistance is the number of commits between HEAD and Tag
+	TagDistance int
+
+	// Major, Minor, Patch, PreRelease and BuildMetadata are parsed from Tag when it is valid SemVer
+	Major         int64
+	Minor         int64
+	Patch         int64
+	PreRelease    string
+	BuildMetadata string
+}
+
+// New detects the version control system for paths[0] and computes a GitVersion scoped to
+// paths. All paths are expected to live within the same repository. tagPattern selects which
+// tags are considered when locating the nearest SemVer tag; an empty pattern defaults to
+// DefaultTagPattern. Only git repositories carry tag/SemVer information
+func New(paths []string, tagPattern string) (*GitVersion, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("gitver: no paths given")
+	}
+
+	cmd, root, err := vcs.Detect(paths[0])
+	if err != nil {
+		return nil, fmt.Errorf("gitver: %w", err)
+	}
+
+	info, err := cmd.Info(root, paths)
+	if err != nil {
+		return nil, fmt.Errorf("gitver: %w", err)
+	}
+
+	var gitCtx *vcs.GitContext
+	if _, isGit := cmd.(vcs.Git); isGit {
+		if gitCtx, err = vcs.NewGitContext(root); err != nil {
+			return nil, fmt.Errorf("gitver: %w", err)
+		}
+	}
+	return FromInfo(info, gitCtx, tagPattern)
+}
+
+// FromInfo builds a GitVersion from vcs.Info that has already been computed, e.g. by a cached
+// core.RepoContext shared across many projects. gitCtx drives the extra git-only tag/SemVer
+// resolution and is nil for projects backed by a non-git VCS, which carry no tag information
+func FromInfo(info vcs.Info, gitCtx *vcs.GitContext, tagPattern string) (*GitVersion, error) {
+	if tagPattern == "" {
+		tagPattern = DefaultTagPattern
+	}
+
+	ver := &GitVersion{
+		Branch:  info.Branch,
+		Commits: info.Commits,
+		Hash:    info.Hash,
+		Dirty:   info.Dirty,
+	}
+
+	if gitCtx != nil {
+		if err := ver.resolveNearestTag(gitCtx, tagPattern); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if ver.User, err = currentUser(); err != nil {
+		return nil, err
+	}
+	return ver, nil
 }
 
 // FormatTemplate formats a GitVersion using a text/template string
@@ -45,36 +122,31 @@ func (ver *GitVersion) String() string {
 	return result
 }
 
-// ForProjects computes the GitVersion for a set of projects relative to the git root
-func (ver *GitVersion) ForProjects(projects []string) error {
-	paths, err := GitProjectPaths(projects)
+// resolveNearestTag looks up the nearest tag matching pattern via gitCtx, populating Tag,
+// TagDistance and the parsed SemVer components when one is found. gitCtx memoizes the tag lookup
+// and commit walk across every project sharing its RepoContext, so the common case of many
+// projects using the same (or default) TagPattern only pays for the walk once
+func (ver *GitVersion) resolveNearestTag(gitCtx *vcs.GitContext, pattern string) error {
+	name, distance, err := gitCtx.NearestTag(pattern)
 	if err != nil {
-		return err
-	}
-
-	if ver.Branch, err = GitBranch(); err != nil {
-		return err
+		return fmt.Errorf("gitver: %w", err)
 	}
-
-	if ver.Commits, err = countCommits(paths); err != nil {
-		return err
+	if name == "" {
+		return nil
 	}
+	ver.Tag = name
+	ver.TagDistance = distance
 
-	rev, err := headCommit(paths)
+	parsed, err := semver.NewVersion(strings.TrimPrefix(ver.Tag, "v"))
 	if err != nil {
-		return err
-	}
-	if ver.Hash, err = revParseShort(rev); err != nil {
-		return err
-	}
-
-	if ver.Dirty, err = checkIsDirty(paths); err != nil {
-		return err
-	}
-
-	if ver.User, err = currentUser(); err != nil {
-		return err
+		// Tag doesn't match "v<semver>" - leave the parsed fields at their zero value
+		return nil
 	}
+	ver.Major = parsed.Major()
+	ver.Minor = parsed.Minor()
+	ver.Patch = parsed.Patch()
+	ver.PreRelease = parsed.Prerelease()
+	ver.BuildMetadata = parsed.Metadata()
 	return nil
 }
 
@@ -96,4 +168,4 @@ func currentUser() (string, error) {
 		sanitizedUserCache.err = nil
 	})
 	return sanitizedUserCache.response, sanitizedUserCache.err
-}
\ No newline at end of file
+}
@@ -0,0 +1,137 @@
+// Package release implements the `pile release` workflow: bumping a project's SemVer
+// version from its nearest git tag and, optionally, creating and pushing the new tag
+package release
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/chrisdail/pile/core"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Bump identifies which component of a SemVer version to increment
+type Bump string
+
+// Supported Bump values
+const (
+	BumpMajor Bump = "major"
+	BumpMinor Bump = "minor"
+	BumpPatch Bump = "patch"
+	BumpDev   Bump = "dev"
+)
+
+// Options controls how Run performs a release
+type Options struct {
+	// Bump selects which SemVer component to increment. BumpDev leaves the version unchanged
+	Bump Bump
+
+	// PushTag creates and pushes an annotated tag for the new version when true
+	PushTag bool
+}
+
+// Run verifies project is releasable, computes the next version from its nearest git tag and
+// rewrites project.Tag accordingly. When opts.PushTag is set it also creates and pushes the
+// corresponding annotated git tag
+func Run(project *core.Project, opts Options) error {
+	if err := checkReleasable(project); err != nil {
+		return err
+	}
+	if opts.Bump == BumpDev && opts.PushTag {
+		return fmt.Errorf("release: %s: --push cannot be combined with --bump=dev, since it leaves the version unchanged", project.Config.Name)
+	}
+
+	current, err := currentVersion(project)
+	if err != nil {
+		return err
+	}
+
+	next := bumpVersion(current, opts.Bump)
+	project.Tag = fmt.Sprintf("v%s", next.String())
+	if project.Config.VersionPrefix != "" {
+		project.Tag = project.Config.VersionPrefix + project.Tag
+	}
+
+	if opts.PushTag {
+		return tagAndPush(project, next)
+	}
+	return nil
+}
+
+// currentVersion returns the SemVer version to bump from. A project with no reachable tag yet
+// (its first-ever release) seeds from v0.0.0 rather than erroring
+func currentVersion(project *core.Project) (semver.Version, error) {
+	if project.GitVersion.Tag == "" {
+		seed, err := semver.NewVersion("0.0.0")
+		return *seed, err
+	}
+
+	current, err := semver.NewVersion(project.GitVersion.Tag)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("release: %s has no valid SemVer tag to bump from: %w", project.Config.Name, err)
+	}
+	return *current, nil
+}
+
+func bumpVersion(current semver.Version, bump Bump) semver.Version {
+	switch bump {
+	case BumpMajor:
+		return current.IncMajor()
+	case BumpMinor:
+		return current.IncMinor()
+	case BumpPatch:
+		return current.IncPatch()
+	default:
+		return current
+	}
+}
+
+// checkReleasable returns an error if project's working tree is dirty or its current branch
+// isn't in its configured ReleaseBranches
+func checkReleasable(project *core.Project) error {
+	if project.GitVersion.Dirty {
+		return fmt.Errorf("release: %s has uncommitted changes", project.Config.Name)
+	}
+
+	branches := project.Config.ReleaseBranches
+	if len(branches) == 0 {
+		return nil
+	}
+	for _, branch := range branches {
+		if branch == project.GitVersion.Branch {
+			return nil
+		}
+	}
+	return fmt.Errorf("release: %s must be released from one of %v, currently on %s", project.Config.Name, branches, project.GitVersion.Branch)
+}
+
+// tagAndPush creates an annotated tag for next at HEAD and pushes it to the default remote
+func tagAndPush(project *core.Project, next semver.Version) error {
+	repo, err := git.PlainOpenWithOptions(project.Dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return fmt.Errorf("release: opening repository: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("release: reading HEAD: %w", err)
+	}
+
+	tagName := fmt.Sprintf("v%s", next.String())
+	_, err = repo.CreateTag(tagName, head.Hash(), &git.CreateTagOptions{
+		Message: fmt.Sprintf("Release %s", tagName),
+		Tagger:  &object.Signature{Name: "pile", Email: "pile@localhost", When: time.Now()},
+	})
+	if err != nil {
+		return fmt.Errorf("release: creating tag %s: %w", tagName, err)
+	}
+
+	refSpec := config.RefSpec(fmt.Sprintf("refs/tags/%s:refs/tags/%s", tagName, tagName))
+	if err := repo.Push(&git.PushOptions{RefSpecs: []config.RefSpec{refSpec}}); err != nil {
+		return fmt.Errorf("release: pushing tag %s: %w", tagName, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/chrisdail/pile/core"
+	"github.com/chrisdail/pile/gitver"
+)
+
+func mustVersion(t *testing.T, value string) semver.Version {
+	t.Helper()
+	v, err := semver.NewVersion(value)
+	if err != nil {
+		t.Fatalf("semver.NewVersion(%q): %v", value, err)
+	}
+	return *v
+}
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		bump Bump
+		want string
+	}{
+		{BumpMajor, "2.0.0"},
+		{BumpMinor, "1.3.0"},
+		{BumpPatch, "1.2.4"},
+		{BumpDev, "1.2.3"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.bump), func(t *testing.T) {
+			current := mustVersion(t, "1.2.3")
+			if got := bumpVersion(current, test.bump); got.String() != test.want {
+				t.Errorf("bumpVersion(1.2.3, %s) = %s, want %s", test.bump, got.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestCurrentVersion(t *testing.T) {
+	t.Run("seeds v0.0.0 for a project with no tag yet", func(t *testing.T) {
+		project := &core.Project{GitVersion: &gitver.GitVersion{}}
+		current, err := currentVersion(project)
+		if err != nil {
+			t.Fatalf("currentVersion: %v", err)
+		}
+		if current.String() != "0.0.0" {
+			t.Errorf("currentVersion = %s, want 0.0.0", current.String())
+		}
+	})
+}
+
+func TestRunRejectsDevPush(t *testing.T) {
+	project := &core.Project{
+		Config:     core.ProjectConfig{Name: "example"},
+		GitVersion: &gitver.GitVersion{Tag: "v1.2.3"},
+	}
+
+	err := Run(project, Options{Bump: BumpDev, PushTag: true})
+	if err == nil {
+		t.Fatal("Run(dev, push) = nil error, want an error since dev leaves the version unchanged")
+	}
+}
@@ -0,0 +1,126 @@
+package vcs
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Git implements Cmd for git repositories, in-process via go-git
+type Git struct{}
+
+// Name identifies this VCS
+func (Git) Name() string { return "git" }
+
+// Marker is the directory that identifies a git repository root
+func (Git) Marker() string { return ".git" }
+
+// Info computes version information for paths using an in-process walk of the commit graph
+func (Git) Info(root string, paths []string) (Info, error) {
+	repo, err := GitRepository(root)
+	if err != nil {
+		return Info{}, err
+	}
+	return gitInfo(repo, root, paths)
+}
+
+// GitRepository opens the git repository rooted at root. Exposed so callers that need
+// lower-level access to the repository (e.g. NewGitContext, which backs gitver's SemVer tag
+// resolution) can reuse the same handle instead of reopening it
+func GitRepository(root string) (*git.Repository, error) {
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		return nil, fmt.Errorf("vcs: opening git repository at %s: %w", root, err)
+	}
+	return repo, nil
+}
+
+func gitInfo(repo *git.Repository, root string, paths []string) (Info, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: getting worktree: %w", err)
+	}
+
+	relPaths := make([]string, len(paths))
+	for i, path := range paths {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return Info{}, fmt.Errorf("vcs: %s is not inside repository %s: %w", path, root, err)
+		}
+		relPaths[i] = filepath.ToSlash(rel)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: reading HEAD: %w", err)
+	}
+
+	commits, err := countGitCommits(repo, head.Hash(), relPaths)
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: counting commits: %w", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: reading worktree status: %w", err)
+	}
+
+	return Info{
+		Branch:  head.Name().Short(),
+		Commits: strconv.Itoa(commits),
+		Hash:    head.Hash().String()[:7],
+		Dirty:   statusTouchesPaths(status, relPaths),
+	}, nil
+}
+
+// countGitCommits walks the commit graph starting at rev, counting commits that touch any of paths
+func countGitCommits(repo *git.Repository, rev plumbing.Hash, paths []string) (int, error) {
+	commitIter, err := repo.Log(&git.LogOptions{From: rev})
+	if err != nil {
+		return 0, err
+	}
+
+	pathIter := object.NewCommitPathIterFromIter(pathWithinFilter(paths), commitIter, false)
+
+	count := 0
+	err = pathIter.ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// pathWithinFilter returns a CommitFilter matching files at or under any of paths. A path of "."
+// (a project at the repository root) matches every file, since filepath.Rel(root, root) yields
+// "." rather than a prefix any real file path would share
+func pathWithinFilter(paths []string) func(string) bool {
+	return func(file string) bool {
+		for _, path := range paths {
+			if path == "." || file == path || strings.HasPrefix(file, path+"/") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// statusTouchesPaths reports whether the worktree status has changes at or under any of paths.
+// As in pathWithinFilter, "." matches every file
+func statusTouchesPaths(status git.Status, paths []string) bool {
+	for file := range status {
+		for _, path := range paths {
+			if path == "." || file == path || strings.HasPrefix(file, path+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
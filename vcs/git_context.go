@@ -0,0 +1,259 @@
+package vcs
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// GitContext caches the expensive, repository-wide state (HEAD, worktree status, the set of
+// commits touching each individual path, the commit order from HEAD, and tags matching a given
+// pattern) so it can be computed once and shared across the Info/NearestTag calls for every
+// project in the repository, instead of re-walking the commit graph and re-reading tags each time.
+//
+// go-git's *git.Repository (and the object cache behind it) isn't safe for concurrent use, and
+// LoadAll calls into a single shared GitContext from many goroutines. mu therefore guards not
+// just the memoization maps but every access to repo: all repository reads happen with mu held,
+// serializing the underlying walks rather than racing them
+type GitContext struct {
+	repo   *git.Repository
+	root   string
+	head   plumbing.Hash
+	branch string
+	status git.Status
+
+	mu            sync.Mutex
+	commitsByPath map[string]map[plumbing.Hash]struct{}
+	commitOrder   []plumbing.Hash
+	tagsByPattern map[string]map[plumbing.Hash]string
+}
+
+// NewGitContext opens the git repository at root and reads its HEAD and worktree status once
+func NewGitContext(root string) (*GitContext, error) {
+	repo, err := GitRepository(root)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("vcs: reading HEAD: %w", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("vcs: getting worktree: %w", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("vcs: reading worktree status: %w", err)
+	}
+
+	return &GitContext{
+		repo:          repo,
+		root:          root,
+		head:          head.Hash(),
+		branch:        head.Name().Short(),
+		status:        status,
+		commitsByPath: map[string]map[plumbing.Hash]struct{}{},
+		tagsByPattern: map[string]map[plumbing.Hash]string{},
+	}, nil
+}
+
+// Info computes Info for paths, reusing the cached HEAD/status and memoizing the set of commits
+// touching each individual path across calls
+func (ctx *GitContext) Info(paths []string) (Info, error) {
+	relPaths := make([]string, len(paths))
+	for i, path := range paths {
+		rel, err := filepath.Rel(ctx.root, path)
+		if err != nil {
+			return Info{}, fmt.Errorf("vcs: %s is not inside repository %s: %w", path, ctx.root, err)
+		}
+		relPaths[i] = filepath.ToSlash(rel)
+	}
+
+	touching := map[plumbing.Hash]struct{}{}
+	for _, path := range relPaths {
+		commits, err := ctx.commitsTouching(path)
+		if err != nil {
+			return Info{}, fmt.Errorf("vcs: counting commits for %s: %w", path, err)
+		}
+		for hash := range commits {
+			touching[hash] = struct{}{}
+		}
+	}
+
+	return Info{
+		Branch:  ctx.branch,
+		Commits: strconv.Itoa(len(touching)),
+		Hash:    ctx.head.String()[:7],
+		Dirty:   statusTouchesPaths(ctx.status, relPaths),
+	}, nil
+}
+
+// commitsTouching returns the set of commit hashes reachable from HEAD that touch path,
+// memoized per path so projects sharing a dependency only pay for the walk once. The walk itself
+// runs with mu held, since the underlying repository isn't safe for concurrent access
+func (ctx *GitContext) commitsTouching(path string) (map[plumbing.Hash]struct{}, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if cached, ok := ctx.commitsByPath[path]; ok {
+		return cached, nil
+	}
+
+	commitIter, err := ctx.repo.Log(&git.LogOptions{From: ctx.head})
+	if err != nil {
+		return nil, err
+	}
+
+	pathIter := object.NewCommitPathIterFromIter(pathWithinFilter([]string{path}), commitIter, false)
+
+	commits := map[plumbing.Hash]struct{}{}
+	err = pathIter.ForEach(func(c *object.Commit) error {
+		commits[c.Hash] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.commitsByPath[path] = commits
+	return commits, nil
+}
+
+// NearestTag returns the name of, and first-parent commit distance from HEAD to, the nearest tag
+// matching pattern, memoized per pattern so projects sharing a TagPattern only pay for the
+// tag/commit walk once. Returns ("", 0, nil) if no tag matches
+func (ctx *GitContext) NearestTag(pattern string) (string, int, error) {
+	tagsByCommit, err := ctx.tagsMatching(pattern)
+	if err != nil {
+		return "", 0, fmt.Errorf("vcs: reading tags: %w", err)
+	}
+	if len(tagsByCommit) == 0 {
+		return "", 0, nil
+	}
+
+	order, err := ctx.commitsFromHead()
+	if err != nil {
+		return "", 0, fmt.Errorf("vcs: walking commits: %w", err)
+	}
+
+	for _, hash := range order {
+		name, ok := tagsByCommit[hash]
+		if !ok {
+			continue
+		}
+
+		distance, err := ctx.firstParentDistance(hash)
+		if err != nil {
+			return "", 0, fmt.Errorf("vcs: measuring distance to %s: %w", name, err)
+		}
+		return name, distance, nil
+	}
+	return "", 0, nil
+}
+
+// firstParentDistance returns the number of commits between HEAD and target when following only
+// first parents, i.e. what `git rev-list --first-parent --count target..HEAD` would report. This
+// is an approximation of "commits between HEAD and Tag" on merge-heavy history: a tag reached
+// only through a non-first-parent branch is not found and the full first-parent chain length is
+// returned instead. Runs with mu held, since the underlying repository isn't safe for concurrent
+// access
+func (ctx *GitContext) firstParentDistance(target plumbing.Hash) (int, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	commit, err := ctx.repo.CommitObject(ctx.head)
+	if err != nil {
+		return 0, err
+	}
+
+	distance := 0
+	for commit.Hash != target {
+		if commit.NumParents() == 0 {
+			return distance, nil
+		}
+		if commit, err = commit.Parent(0); err != nil {
+			return 0, err
+		}
+		distance++
+	}
+	return distance, nil
+}
+
+// tagsMatching returns the tag names matching pattern, keyed by the hash of the commit they
+// point to, memoized per pattern. Runs with mu held, since the underlying repository isn't safe
+// for concurrent access
+func (ctx *GitContext) tagsMatching(pattern string) (map[plumbing.Hash]string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if cached, ok := ctx.tagsByPattern[pattern]; ok {
+		return cached, nil
+	}
+
+	tagRefs, err := ctx.repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[plumbing.Hash]string{}
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		matched, err := path.Match(pattern, name)
+		if err != nil || !matched {
+			return err
+		}
+
+		hash := ref.Hash()
+		if tagObj, err := ctx.repo.TagObject(hash); err == nil {
+			hash = tagObj.Target
+		}
+		result[hash] = name
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.tagsByPattern[pattern] = result
+	return result, nil
+}
+
+// commitsFromHead returns the commits reachable from HEAD in traversal order, memoized so the
+// walk only happens once regardless of how many patterns NearestTag is asked about. Runs with mu
+// held, since the underlying repository isn't safe for concurrent access
+func (ctx *GitContext) commitsFromHead() ([]plumbing.Hash, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if ctx.commitOrder != nil {
+		return ctx.commitOrder, nil
+	}
+
+	commitIter, err := ctx.repo.Log(&git.LogOptions{From: ctx.head})
+	if err != nil {
+		return nil, err
+	}
+	defer commitIter.Close()
+
+	var order []plumbing.Hash
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		order = append(order, c.Hash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.commitOrder = order
+	return order, nil
+}
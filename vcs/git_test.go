@@ -0,0 +1,44 @@
+package vcs
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+)
+
+func TestPathWithinFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		file  string
+		want  bool
+	}{
+		{"exact match", []string{"service"}, "service", true},
+		{"nested match", []string{"service"}, "service/main.go", true},
+		{"sibling prefix does not match", []string{"service"}, "service-other/main.go", false},
+		{"unrelated path", []string{"service"}, "other/main.go", false},
+		{"root project matches everything", []string{"."}, "anything/deep/file.go", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := pathWithinFilter(test.paths)(test.file); got != test.want {
+				t.Errorf("pathWithinFilter(%v)(%q) = %v, want %v", test.paths, test.file, got, test.want)
+			}
+		})
+	}
+}
+
+func TestStatusTouchesPaths(t *testing.T) {
+	status := git.Status{"service/main.go": &git.FileStatus{}}
+
+	if touches := statusTouchesPaths(status, []string{"other"}); touches {
+		t.Errorf("statusTouchesPaths with unrelated path = true, want false")
+	}
+	if !statusTouchesPaths(status, []string{"service"}) {
+		t.Errorf("statusTouchesPaths with matching path = false, want true")
+	}
+	if !statusTouchesPaths(status, []string{"."}) {
+		t.Errorf("statusTouchesPaths with root path \".\" = false, want true")
+	}
+}
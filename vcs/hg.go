@@ -0,0 +1,101 @@
+package vcs
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Hg implements Cmd for Mercurial repositories by shelling out to the hg CLI
+type Hg struct{}
+
+// Name identifies this VCS
+func (Hg) Name() string { return "hg" }
+
+// Marker is the directory that identifies a Mercurial repository root
+func (Hg) Marker() string { return ".hg" }
+
+// Info computes version information for paths by shelling out to hg
+func (Hg) Info(root string, paths []string) (Info, error) {
+	branch, err := runHg(root, "branch")
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: hg branch: %w", err)
+	}
+
+	hash, err := runHg(root, "log", "-r", ".", "--template", "{node|short}")
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: hg log: %w", err)
+	}
+
+	relPaths := make([]string, len(paths))
+	for i, path := range paths {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return Info{}, fmt.Errorf("vcs: %s is not inside repository %s: %w", path, root, err)
+		}
+		relPaths[i] = filepath.ToSlash(rel)
+	}
+
+	commits, err := hgCommitCount(root, relPaths)
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: hg log: %w", err)
+	}
+
+	dirty, err := hgIsDirty(root, relPaths)
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: hg status: %w", err)
+	}
+
+	return Info{
+		Branch:  branch,
+		Commits: strconv.Itoa(commits),
+		Hash:    hash,
+		Dirty:   dirty,
+	}, nil
+}
+
+// hgCommitCount counts ancestors of the working copy that touch any of paths, which must be
+// root-relative slash paths
+func hgCommitCount(root string, paths []string) (int, error) {
+	rev := fmt.Sprintf("ancestors(.) and (%s)", hgFileSet(paths))
+	output, err := runHg(root, "log", "-r", rev, "--template", "{node|short}\n")
+	if err != nil {
+		return 0, err
+	}
+	if output == "" {
+		return 0, nil
+	}
+	return len(strings.Split(output, "\n")), nil
+}
+
+// hgIsDirty reports whether any of paths, which must be root-relative slash paths, have
+// uncommitted changes
+func hgIsDirty(root string, paths []string) (bool, error) {
+	args := append([]string{"status"}, paths...)
+	output, err := runHg(root, args...)
+	if err != nil {
+		return false, err
+	}
+	return output != "", nil
+}
+
+// hgFileSet builds an hg revset matching any of paths, e.g. file("a") or file("b")
+func hgFileSet(paths []string) string {
+	clauses := make([]string, len(paths))
+	for i, path := range paths {
+		clauses[i] = fmt.Sprintf("file(%q)", path)
+	}
+	return strings.Join(clauses, " or ")
+}
+
+func runHg(dir string, args ...string) (string, error) {
+	cmd := exec.Command("hg", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
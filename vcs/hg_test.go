@@ -0,0 +1,22 @@
+package vcs
+
+import "testing"
+
+func TestHgFileSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{"single path", []string{"service"}, `file("service")`},
+		{"multiple paths", []string{"service", "shared/lib"}, `file("service") or file("shared/lib")`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := hgFileSet(test.paths); got != test.want {
+				t.Errorf("hgFileSet(%v) = %q, want %q", test.paths, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package vcs
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Svn implements Cmd for Subversion checkouts by shelling out to the svn CLI
+type Svn struct{}
+
+// Name identifies this VCS
+func (Svn) Name() string { return "svn" }
+
+// Marker is the directory that identifies a Subversion working copy root
+func (Svn) Marker() string { return ".svn" }
+
+// Info computes version information for paths by shelling out to svn. The branch is read from
+// the checkout root, but the revision is scoped to paths so a project's version reflects only
+// the paths that make it up, not the whole checkout
+func (Svn) Info(root string, paths []string) (Info, error) {
+	rootInfo, err := runSvn(root, "info")
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: svn info: %w", err)
+	}
+
+	url, _, err := parseSvnInfo(rootInfo)
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: svn info: %w", err)
+	}
+
+	revision, err := maxChangedRevision(root, paths)
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: svn info: %w", err)
+	}
+
+	dirty, err := svnIsDirty(paths)
+	if err != nil {
+		return Info{}, fmt.Errorf("vcs: svnversion: %w", err)
+	}
+
+	return Info{
+		Branch:  path.Base(url),
+		Commits: revision,
+		Hash:    revision,
+		Dirty:   dirty,
+	}, nil
+}
+
+// maxChangedRevision returns the highest "Last Changed Rev" across paths, queried individually
+// via `svn info <path>`, so a project depending on only part of the checkout doesn't pick up an
+// unrelated project's latest revision
+func maxChangedRevision(root string, paths []string) (string, error) {
+	max := 0
+	for _, p := range paths {
+		output, err := runSvn(root, "info", p)
+		if err != nil {
+			return "", err
+		}
+
+		_, revision, err := parseSvnInfo(output)
+		if err != nil {
+			return "", err
+		}
+
+		n, err := strconv.Atoi(revision)
+		if err != nil {
+			return "", fmt.Errorf("parsing revision %q for %s: %w", revision, p, err)
+		}
+		if n > max {
+			max = n
+		}
+	}
+	return strconv.Itoa(max), nil
+}
+
+// parseSvnInfo extracts the repository URL and last changed revision from `svn info` output
+func parseSvnInfo(output string) (url, revision string, err error) {
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "URL: "):
+			url = strings.TrimPrefix(line, "URL: ")
+		case strings.HasPrefix(line, "Last Changed Rev: "):
+			revision = strings.TrimPrefix(line, "Last Changed Rev: ")
+		}
+	}
+	if url == "" || revision == "" {
+		return "", "", fmt.Errorf("could not parse URL/Last Changed Rev from svn info output")
+	}
+	return url, revision, nil
+}
+
+// svnIsDirty reports whether `svnversion` indicates local modifications (a trailing "M")
+// for any of paths
+func svnIsDirty(paths []string) (bool, error) {
+	for _, p := range paths {
+		cmd := exec.Command("svnversion", p)
+		output, err := cmd.Output()
+		if err != nil {
+			return false, err
+		}
+		if strings.Contains(string(output), "M") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func runSvn(dir string, args ...string) (string, error) {
+	cmd := exec.Command("svn", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
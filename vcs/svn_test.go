@@ -0,0 +1,29 @@
+package vcs
+
+import "testing"
+
+func TestParseSvnInfo(t *testing.T) {
+	output := "Path: .\n" +
+		"URL: https://svn.example.com/repo/trunk/service\n" +
+		"Repository Root: https://svn.example.com/repo\n" +
+		"Revision: 1042\n" +
+		"Last Changed Rev: 1038\n" +
+		"Last Changed Date: 2024-01-01\n"
+
+	url, revision, err := parseSvnInfo(output)
+	if err != nil {
+		t.Fatalf("parseSvnInfo: %v", err)
+	}
+	if url != "https://svn.example.com/repo/trunk/service" {
+		t.Errorf("url = %q, want trunk/service URL", url)
+	}
+	if revision != "1038" {
+		t.Errorf("revision = %q, want 1038 (Last Changed Rev, not Revision)", revision)
+	}
+}
+
+func TestParseSvnInfoMissingFields(t *testing.T) {
+	if _, _, err := parseSvnInfo("Path: .\n"); err == nil {
+		t.Fatal("parseSvnInfo with no URL/Last Changed Rev = nil error, want an error")
+	}
+}
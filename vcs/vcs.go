@@ -0,0 +1,58 @@
+// Package vcs abstracts the version control system backing a project, modeled after
+// Go's cmd/go/internal/vcs. It lets pile compute version information from git, Mercurial or
+// Subversion checkouts through a single interface
+package vcs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Info is the version control state of a set of paths within a repository
+type Info struct {
+	Branch  string
+	Commits string
+	Hash    string
+	Dirty   bool
+}
+
+// Cmd implements version control operations for one VCS
+type Cmd interface {
+	// Name identifies this VCS, e.g. "git"
+	Name() string
+	// Marker is the file or directory (relative to a repository root) that identifies it, e.g. ".git"
+	Marker() string
+	// Info computes version information for paths, which must all live under root
+	Info(root string, paths []string) (Info, error)
+}
+
+// cmds are the supported VCS implementations, tried in order by Detect
+var cmds = []Cmd{
+	Git{},
+	Hg{},
+	Svn{},
+}
+
+// Detect walks upward from dir looking for a VCS root marker (.git, .hg, .svn) and returns the
+// matching Cmd along with the root directory it was found in
+func Detect(dir string) (Cmd, string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("vcs: resolving %s: %w", dir, err)
+	}
+
+	for {
+		for _, cmd := range cmds {
+			if _, err := os.Stat(filepath.Join(current, cmd.Marker())); err == nil {
+				return cmd, current, nil
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil, "", fmt.Errorf("vcs: no repository found above %s", dir)
+		}
+		current = parent
+	}
+}